@@ -0,0 +1,52 @@
+package router
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/origin/pkg/router/log"
+)
+
+// LogConfig holds the flags that control the process-wide logger installed
+// by Apply.
+type LogConfig struct {
+	// Verbosity follows glog's -v convention: V(0) is always enabled, and
+	// each increment enables one more V(n) call site.
+	Verbosity int
+
+	// Format selects the logger's output encoding. Supported values are
+	// "json" (the default, suited to log aggregation) and "text" (suited to
+	// a human watching a terminal).
+	Format string
+}
+
+// AddFlags registers the flags controlling the process logger onto flag.
+func (cfg *LogConfig) AddFlags(flag *pflag.FlagSet) {
+	flag.IntVar(&cfg.Verbosity, "v", 0, "Log verbosity level")
+	flag.StringVar(&cfg.Format, "log-format", "json", "Log output format, one of: json, text")
+}
+
+// Apply builds the logger described by cfg and installs it as the
+// process-wide logger via log.SetLogger. It must be called once, after
+// flags are parsed, before starting any controllers.
+func (cfg *LogConfig) Apply() error {
+	var (
+		logger logr.Logger
+		err    error
+	)
+	switch cfg.Format {
+	case "", "json":
+		logger, err = log.NewZapLogger(cfg.Verbosity)
+	case "text":
+		logger, err = log.NewZapLoggerText(cfg.Verbosity)
+	default:
+		return fmt.Errorf("unrecognized log format %q: must be %q or %q", cfg.Format, "json", "text")
+	}
+	if err != nil {
+		return fmt.Errorf("unable to initialize logger: %v", err)
+	}
+	log.SetLogger(logger)
+	return nil
+}
@@ -0,0 +1,41 @@
+// Package router contains the command-line wiring shared by the router
+// binaries: flag registration and translating flag values into the
+// controller types in pkg/router/controller.
+package router
+
+import (
+	"github.com/spf13/pflag"
+
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+
+	"github.com/openshift/origin/pkg/router/controller"
+)
+
+// RouterSelectionConfig holds the flags that control which
+// controller.RejectionRecorder a router binary wires into its UniqueHost
+// plugin.
+type RouterSelectionConfig struct {
+	// RouterName identifies this router instance in recorded events and
+	// status conditions.
+	RouterName string
+
+	// EnableEventRejections publishes route rejections as kube events
+	// against the rejected route, in addition to the router's own logs.
+	EnableEventRejections bool
+}
+
+// AddFlags registers the flags controlling router selection onto flag.
+func (cfg *RouterSelectionConfig) AddFlags(flag *pflag.FlagSet) {
+	flag.StringVar(&cfg.RouterName, "name", "public", "The name the router will identify itself with in the route status and any recorded events")
+	flag.BoolVar(&cfg.EnableEventRejections, "record-rejections-as-events", false, "Publish route rejections as events against the rejected route, in addition to the router's own logs")
+}
+
+// RejectionRecorder returns the controller.RejectionRecorder selected by
+// this configuration. events is only consulted when EnableEventRejections
+// is set.
+func (cfg *RouterSelectionConfig) RejectionRecorder(events kclient.EventNamespacer) controller.RejectionRecorder {
+	if cfg.EnableEventRejections {
+		return controller.NewEventRejectionRecorder(events, cfg.RouterName)
+	}
+	return controller.LogRejections
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+
+	routeapi "github.com/openshift/origin/pkg/route/api"
+	"github.com/openshift/origin/pkg/router/log"
+)
+
+// EventRejectionRecorder is a RejectionRecorder that publishes a real kapi.Event
+// against the rejected route, so that rejections show up in `oc describe route`
+// and on the cluster event stream instead of only in the router's own logs.
+type EventRejectionRecorder struct {
+	source kapi.EventSource
+	events kclient.EventNamespacer
+	log    logr.Logger
+}
+
+// NewEventRejectionRecorder returns a RejectionRecorder that records rejections
+// as events in the namespace of the rejected route. routerName identifies the
+// router instance that made the decision and is recorded as the event's
+// Source.Component so that multiple routers sharing a cluster can be told apart.
+func NewEventRejectionRecorder(events kclient.EventNamespacer, routerName string) *EventRejectionRecorder {
+	return &EventRejectionRecorder{
+		source: kapi.EventSource{Component: routerName},
+		events: events,
+		log:    log.WithName("eventRecorder"),
+	}
+}
+
+// RecordRouteRejection records that a route was rejected or replaced for the
+// given reason. Events are named after the route and reason so that repeated
+// rejections of the same kind update a single event (bumping its Count)
+// rather than creating a new event every resync.
+func (r *EventRejectionRecorder) RecordRouteRejection(route *routeapi.Route, reason, message string) {
+	ref, err := kapi.GetReference(route)
+	if err != nil {
+		r.log.Error(err, "unable to record rejection event", "route", routeNameKey(route))
+		return
+	}
+
+	events := r.events.Events(route.Namespace)
+	name := fmt.Sprintf("%s.%s", route.Name, reason)
+	now := unversioned.Now()
+
+	if existing, err := events.Get(name); err == nil {
+		existing.Count++
+		existing.LastTimestamp = now
+		existing.Message = message
+		existing.Source = r.source
+		if _, err := events.Update(existing); err != nil {
+			r.log.Error(err, "unable to update rejection event", "route", routeNameKey(route))
+		}
+		return
+	}
+
+	event := &kapi.Event{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:      name,
+			Namespace: route.Namespace,
+		},
+		InvolvedObject: *ref,
+		Reason:         reason,
+		Message:        message,
+		Source:         r.source,
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+		Type:           kapi.EventTypeWarning,
+	}
+	if _, err := events.Create(event); err != nil {
+		r.log.Error(err, "unable to record rejection event", "route", routeNameKey(route))
+	}
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/kubernetes/pkg/watch"
+
+	"github.com/openshift/origin/pkg/router/log"
+)
+
+// TestLogRejections_UsesCurrentLogger guards against LogRejections caching a
+// logger at package-init time: it installs a test logger well after init has
+// already run and expects RecordRouteRejection to still show up in it,
+// rather than being silently swallowed by whatever logger was current at
+// var-initialization.
+func TestLogRejections_UsesCurrentLogger(t *testing.T) {
+	logger, sink := log.NewTestLogger()
+	log.SetLogger(logger)
+	defer log.SetLogger(logr.Discard())
+
+	route := newRoute("team-a", "r1", "example.com", "", false, 1)
+	LogRejections.RecordRouteRejection(route, "HostAlreadyClaimed", "already claimed")
+
+	lines := sink.Lines()
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 log line, got %d: %v", len(lines), lines)
+	}
+	if lines[0].Message != "rejected route" {
+		t.Errorf("message = %q, want %q", lines[0].Message, "rejected route")
+	}
+	if lines[0].Name != "rejections" {
+		t.Errorf("logger name = %q, want %q", lines[0].Name, "rejections")
+	}
+}
+
+// TestNewUniqueHostWithLogger_CapturesLogLines exercises the constructor
+// option threading a caller-supplied logger through UniqueHost, as opposed
+// to NewUniqueHost's fixed log.WithName("uniqueHost").
+func TestNewUniqueHostWithLogger_CapturesLogLines(t *testing.T) {
+	logger, sink := log.NewTestLogger()
+	plugin := &testPlugin{}
+	p := NewUniqueHostWithLogger(plugin, HostForRoute, LogRejections, logger)
+
+	route := newRoute("team-a", "r1", "", "", false, 1)
+	if err := p.HandleRoute(watch.Added, route); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	found := false
+	for _, line := range sink.Lines() {
+		if line.Message == "route has no host value" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the supplied logger to capture the no-host rejection, got %v", sink.Lines())
+	}
+}
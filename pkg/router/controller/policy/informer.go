@@ -0,0 +1,74 @@
+package policy
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-logr/logr"
+
+	"k8s.io/kubernetes/pkg/client/cache"
+
+	"github.com/openshift/origin/pkg/router/log"
+)
+
+// InformerPolicyProvider is a PolicyProvider backed by a cache.SharedInformer
+// watching the cluster's AdminRouteAdmissionPolicy objects. The compiled rule
+// set is rebuilt on every informer update and swapped in atomically, so
+// Lookup never blocks on the informer's lock.
+type InformerPolicyProvider struct {
+	informer cache.SharedIndexInformer
+
+	// current holds the most recently compiled ruleSet.
+	current atomic.Value
+
+	// compileLock serializes rebuilds triggered by informer callbacks.
+	compileLock sync.Mutex
+
+	log logr.Logger
+}
+
+// NewInformerPolicyProvider returns a PolicyProvider kept in sync with the
+// AdminRouteAdmissionPolicy objects served by informer's store. toRule
+// converts a single informer object into an AdminRouteAdmissionPolicy.
+func NewInformerPolicyProvider(informer cache.SharedIndexInformer, toRule func(obj interface{}) (AdminRouteAdmissionPolicy, error)) *InformerPolicyProvider {
+	p := &InformerPolicyProvider{informer: informer, log: log.WithName("policy")}
+	p.current.Store(ruleSet{})
+
+	rebuild := func(interface{}) { p.rebuild(toRule) }
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    rebuild,
+		UpdateFunc: func(_, obj interface{}) { rebuild(obj) },
+		DeleteFunc: rebuild,
+	})
+
+	return p
+}
+
+// rebuild recompiles the rule set from the informer's current store contents.
+func (p *InformerPolicyProvider) rebuild(toRule func(obj interface{}) (AdminRouteAdmissionPolicy, error)) {
+	p.compileLock.Lock()
+	defer p.compileLock.Unlock()
+
+	objs := p.informer.GetStore().List()
+	rules := make([]AdminRouteAdmissionPolicy, 0, len(objs))
+	for _, obj := range objs {
+		rule, err := toRule(obj)
+		if err != nil {
+			p.log.Error(err, "skipping invalid AdminRouteAdmissionPolicy")
+			continue
+		}
+		rules = append(rules, rule)
+	}
+
+	set, err := newRuleSet(rules)
+	if err != nil {
+		p.log.Error(err, "unable to compile admission policy rules")
+		return
+	}
+	p.current.Store(set)
+}
+
+// Lookup implements PolicyProvider.
+func (p *InformerPolicyProvider) Lookup(host string) (AdminRouteAdmissionPolicy, bool) {
+	return p.current.Load().(ruleSet).Lookup(host)
+}
@@ -0,0 +1,107 @@
+package policy
+
+import (
+	"regexp"
+	"strings"
+)
+
+// compiledRule pairs an AdminRouteAdmissionPolicy with whatever was derived
+// from its HostPattern so matching doesn't re-parse it on every lookup.
+type compiledRule struct {
+	AdminRouteAdmissionPolicy
+
+	// suffix is the normalized "*.suffix" suffix, set when the pattern is a
+	// wildcard match.
+	suffix string
+	// exact is the normalized exact host, set when the pattern is neither a
+	// wildcard nor a regex.
+	exact string
+	// expr is the compiled regex, set when Regex is true.
+	expr *regexp.Regexp
+}
+
+func compileRule(rule AdminRouteAdmissionPolicy) (compiledRule, error) {
+	c := compiledRule{AdminRouteAdmissionPolicy: rule}
+	switch {
+	case rule.Regex:
+		expr, err := regexp.Compile(rule.HostPattern)
+		if err != nil {
+			return compiledRule{}, err
+		}
+		c.expr = expr
+	case strings.HasPrefix(rule.HostPattern, "*."):
+		c.suffix = normalizeHost(strings.TrimPrefix(rule.HostPattern, "*."))
+	default:
+		c.exact = normalizeHost(rule.HostPattern)
+	}
+	return c, nil
+}
+
+// normalizeHost lower-cases host and strips a single trailing dot so that
+// "Example.com." and "example.com" are treated as the same host.
+func normalizeHost(host string) string {
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
+
+// matches reports whether host satisfies the compiled rule.
+func (c compiledRule) matches(host string) bool {
+	host = normalizeHost(host)
+	switch {
+	case c.expr != nil:
+		return c.expr.MatchString(host)
+	case c.suffix != "":
+		return host == c.suffix || strings.HasSuffix(host, "."+c.suffix)
+	default:
+		return host == c.exact
+	}
+}
+
+// specificity orders rules so the most specific match wins: exact hosts beat
+// wildcard suffixes, and among wildcards the longer (more specific) suffix
+// wins.
+func (c compiledRule) specificity() int {
+	switch {
+	case c.expr != nil:
+		return 0
+	case c.suffix != "":
+		return 1 + len(c.suffix)
+	default:
+		return 1 << 30
+	}
+}
+
+// ruleSet holds a compiled, immutable snapshot of policy rules and answers
+// Lookup queries against it. StaticPolicyProvider embeds one;
+// InformerPolicyProvider swaps in a new one on every rebuild.
+type ruleSet struct {
+	rules []compiledRule
+}
+
+func newRuleSet(rules []AdminRouteAdmissionPolicy) (ruleSet, error) {
+	compiled := make([]compiledRule, 0, len(rules))
+	for _, rule := range rules {
+		c, err := compileRule(rule)
+		if err != nil {
+			return ruleSet{}, err
+		}
+		compiled = append(compiled, c)
+	}
+	return ruleSet{rules: compiled}, nil
+}
+
+func (s ruleSet) Lookup(host string) (AdminRouteAdmissionPolicy, bool) {
+	best := -1
+	bestSpecificity := -1
+	for i, rule := range s.rules {
+		if !rule.matches(host) {
+			continue
+		}
+		if spec := rule.specificity(); spec > bestSpecificity {
+			best, bestSpecificity = i, spec
+		}
+	}
+	if best < 0 {
+		return AdminRouteAdmissionPolicy{}, false
+	}
+	return s.rules[best].AdminRouteAdmissionPolicy, true
+}
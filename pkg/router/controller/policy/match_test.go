@@ -0,0 +1,115 @@
+package policy
+
+import "testing"
+
+func TestCompiledRuleMatches(t *testing.T) {
+	cases := []struct {
+		name    string
+		rule    AdminRouteAdmissionPolicy
+		host    string
+		matches bool
+	}{
+		{
+			name:    "exact match",
+			rule:    AdminRouteAdmissionPolicy{HostPattern: "www.example.com"},
+			host:    "www.example.com",
+			matches: true,
+		},
+		{
+			name:    "exact match is case and trailing-dot insensitive",
+			rule:    AdminRouteAdmissionPolicy{HostPattern: "WWW.Example.com."},
+			host:    "www.example.com",
+			matches: true,
+		},
+		{
+			name:    "exact pattern does not match a subdomain",
+			rule:    AdminRouteAdmissionPolicy{HostPattern: "example.com"},
+			host:    "www.example.com",
+			matches: false,
+		},
+		{
+			name:    "wildcard suffix matches subdomain",
+			rule:    AdminRouteAdmissionPolicy{HostPattern: "*.example.com"},
+			host:    "www.example.com",
+			matches: true,
+		},
+		{
+			name:    "wildcard suffix matches the bare suffix itself",
+			rule:    AdminRouteAdmissionPolicy{HostPattern: "*.example.com"},
+			host:    "example.com",
+			matches: true,
+		},
+		{
+			name:    "wildcard suffix does not match an unrelated host",
+			rule:    AdminRouteAdmissionPolicy{HostPattern: "*.example.com"},
+			host:    "example.org",
+			matches: false,
+		},
+		{
+			name:    "regex pattern",
+			rule:    AdminRouteAdmissionPolicy{HostPattern: "^[a-z]+\\.example\\.com$", Regex: true},
+			host:    "api.example.com",
+			matches: true,
+		},
+		{
+			name:    "regex pattern rejects non-matching host",
+			rule:    AdminRouteAdmissionPolicy{HostPattern: "^[a-z]+\\.example\\.com$", Regex: true},
+			host:    "api1.example.com",
+			matches: false,
+		},
+	}
+
+	for _, tc := range cases {
+		c, err := compileRule(tc.rule)
+		if err != nil {
+			t.Fatalf("%s: compileRule returned error: %v", tc.name, err)
+		}
+		if got := c.matches(tc.host); got != tc.matches {
+			t.Errorf("%s: matches(%q) = %v, want %v", tc.name, tc.host, got, tc.matches)
+		}
+	}
+}
+
+func TestRuleSetLookupSpecificity(t *testing.T) {
+	rules := []AdminRouteAdmissionPolicy{
+		{Name: "wildcard-com", HostPattern: "*.example.com", Mode: OwnershipStrict},
+		{Name: "wildcard-api", HostPattern: "*.api.example.com", Mode: OwnershipInterNamespaceAllowed},
+		{Name: "exact", HostPattern: "www.example.com", Mode: OwnershipPathExclusive},
+		{Name: "regex", HostPattern: "^regex\\.example\\.com$", Regex: true, Mode: OwnershipStrict},
+	}
+	set, err := newRuleSet(rules)
+	if err != nil {
+		t.Fatalf("newRuleSet returned error: %v", err)
+	}
+
+	cases := []struct {
+		host     string
+		wantName string
+		wantOK   bool
+	}{
+		{host: "www.example.com", wantName: "exact", wantOK: true},
+		{host: "foo.api.example.com", wantName: "wildcard-api", wantOK: true},
+		{host: "foo.example.com", wantName: "wildcard-com", wantOK: true},
+		{host: "regex.example.com", wantName: "regex", wantOK: true},
+		{host: "unrelated.org", wantName: "", wantOK: false},
+	}
+	for _, tc := range cases {
+		rule, ok := set.Lookup(tc.host)
+		if ok != tc.wantOK {
+			t.Errorf("Lookup(%q) ok = %v, want %v", tc.host, ok, tc.wantOK)
+			continue
+		}
+		if ok && rule.Name != tc.wantName {
+			t.Errorf("Lookup(%q) = rule %q, want %q", tc.host, rule.Name, tc.wantName)
+		}
+	}
+}
+
+func TestNewRuleSetInvalidRegex(t *testing.T) {
+	_, err := newRuleSet([]AdminRouteAdmissionPolicy{
+		{Name: "bad-regex", HostPattern: "(", Regex: true},
+	})
+	if err == nil {
+		t.Fatalf("expected an error compiling an invalid regex pattern")
+	}
+}
@@ -0,0 +1,38 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// StaticPolicyProvider is a PolicyProvider backed by a fixed set of rules,
+// given directly or loaded once from a file. It is primarily useful for tests and for simple
+// deployments that don't want to run the informer-backed provider.
+type StaticPolicyProvider struct {
+	ruleSet
+}
+
+// NewStaticPolicyProvider returns a PolicyProvider that always answers
+// Lookup from the given rules.
+func NewStaticPolicyProvider(rules []AdminRouteAdmissionPolicy) (*StaticPolicyProvider, error) {
+	set, err := newRuleSet(rules)
+	if err != nil {
+		return nil, err
+	}
+	return &StaticPolicyProvider{ruleSet: set}, nil
+}
+
+// NewStaticPolicyProviderFromFile reads a JSON-encoded []AdminRouteAdmissionPolicy
+// from path and returns a PolicyProvider serving it.
+func NewStaticPolicyProviderFromFile(path string) (*StaticPolicyProvider, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read admission policy file %s: %v", path, err)
+	}
+	var rules []AdminRouteAdmissionPolicy
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("unable to parse admission policy file %s: %v", path, err)
+	}
+	return NewStaticPolicyProvider(rules)
+}
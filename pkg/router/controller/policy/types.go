@@ -0,0 +1,53 @@
+// Package policy provides cluster-scoped admission rules that gate which
+// namespaces may claim which route hostnames, as an alternative to the
+// router's default "first namespace wins" behavior.
+package policy
+
+// OwnershipMode describes how a hostname claim matched by a rule is shared
+// between the namespaces allowed to use it.
+type OwnershipMode string
+
+const (
+	// OwnershipStrict allows only a single namespace to ever hold the
+	// matched host, identical to the router's default oldest-wins behavior
+	// but restricted to the rule's AllowedNamespaces.
+	OwnershipStrict OwnershipMode = "Strict"
+
+	// OwnershipInterNamespaceAllowed allows routes from any namespace in
+	// AllowedNamespaces to share the matched host, as long as their paths
+	// do not collide.
+	OwnershipInterNamespaceAllowed OwnershipMode = "InterNamespaceAllowed"
+
+	// OwnershipPathExclusive allows routes from any namespace in
+	// AllowedNamespaces to share the matched host, but a given path may
+	// only ever be claimed by one namespace at a time (oldest wins, scoped
+	// to the allowed set).
+	OwnershipPathExclusive OwnershipMode = "PathExclusive"
+)
+
+// AdminRouteAdmissionPolicy maps a hostname pattern to the namespaces allowed
+// to claim hosts matching it and the ownership semantics they share it under.
+type AdminRouteAdmissionPolicy struct {
+	// Name identifies the rule for logging and status purposes.
+	Name string
+	// HostPattern is matched against a route's host. It may be an exact
+	// host, a wildcard of the form "*.suffix", or, when Regex is true, an
+	// arbitrary regular expression.
+	HostPattern string
+	// Regex indicates HostPattern should be compiled and matched as a
+	// regular expression rather than an exact or "*.suffix" pattern.
+	Regex bool
+	// AllowedNamespaces is the set of namespaces permitted to claim a host
+	// matching HostPattern. A nil or empty set denies the host to every
+	// namespace.
+	AllowedNamespaces []string
+	// Mode controls how AllowedNamespaces share a matched host.
+	Mode OwnershipMode
+}
+
+// PolicyProvider returns the admission policy currently in effect. Lookup
+// returns the most specific rule matching host, or ok=false if no rule
+// applies and the router should fall back to its default behavior.
+type PolicyProvider interface {
+	Lookup(host string) (rule AdminRouteAdmissionPolicy, ok bool)
+}
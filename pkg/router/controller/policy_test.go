@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/util/sets"
+	"k8s.io/kubernetes/pkg/watch"
+
+	"github.com/openshift/origin/pkg/router/controller/policy"
+)
+
+func newPolicyTestPlugin(t *testing.T, rules []policy.AdminRouteAdmissionPolicy) (*UniqueHost, *testPlugin) {
+	provider, err := policy.NewStaticPolicyProvider(rules)
+	if err != nil {
+		t.Fatalf("unable to build policy provider: %v", err)
+	}
+	plugin := &testPlugin{}
+	p := NewUniqueHost(plugin, HostForRoute, LogRejections)
+	p.SetAdmissionPolicy(provider)
+	return p, plugin
+}
+
+func TestHandleRoute_PolicyStrict(t *testing.T) {
+	p, _ := newPolicyTestPlugin(t, []policy.AdminRouteAdmissionPolicy{
+		{Name: "strict", HostPattern: "example.com", Mode: policy.OwnershipStrict, AllowedNamespaces: []string{"team-a", "team-b"}},
+	})
+
+	first := newRoute("team-a", "r1", "example.com", "", false, 1)
+	if err := p.HandleRoute(watch.Added, first); err != nil {
+		t.Fatalf("unexpected error admitting first route: %v", err)
+	}
+
+	second := newRoute("team-b", "r2", "example.com", "", false, 2)
+	if err := p.HandleRoute(watch.Added, second); err == nil {
+		t.Fatalf("expected second namespace to be rejected under Strict mode")
+	}
+
+	denied := newRoute("team-c", "r3", "example.com", "", false, 3)
+	if err := p.HandleRoute(watch.Added, denied); err == nil {
+		t.Fatalf("expected namespace outside AllowedNamespaces to be denied")
+	}
+}
+
+func TestHandleRoute_PolicyInterNamespaceAllowed(t *testing.T) {
+	p, plugin := newPolicyTestPlugin(t, []policy.AdminRouteAdmissionPolicy{
+		{Name: "shared", HostPattern: "example.com", Mode: policy.OwnershipInterNamespaceAllowed, AllowedNamespaces: []string{"team-a", "team-b"}},
+	})
+
+	a := newRoute("team-a", "r1", "example.com", "/a", false, 1)
+	b := newRoute("team-b", "r2", "example.com", "/b", false, 2)
+	if err := p.HandleRoute(watch.Added, a); err != nil {
+		t.Fatalf("unexpected error admitting team-a route: %v", err)
+	}
+	if err := p.HandleRoute(watch.Added, b); err != nil {
+		t.Fatalf("unexpected error admitting team-b route on a different path: %v", err)
+	}
+
+	conflict := newRoute("team-a", "r3", "example.com", "/b", false, 3)
+	if err := p.HandleRoute(watch.Added, conflict); err == nil {
+		t.Fatalf("expected path conflict to be rejected")
+	}
+
+	routes, ok := p.RoutesForHost("example.com")
+	if !ok || len(routes) != 2 {
+		t.Fatalf("expected both namespaces to share the host, got %v (ok=%v)", routes, ok)
+	}
+	if len(plugin.deleted) != 0 {
+		t.Errorf("no route should have been evicted, got %v", plugin.deleted)
+	}
+}
+
+func TestHandleRoute_PolicyPathExclusive(t *testing.T) {
+	p, plugin := newPolicyTestPlugin(t, []policy.AdminRouteAdmissionPolicy{
+		{Name: "path-exclusive", HostPattern: "example.com", Mode: policy.OwnershipPathExclusive, AllowedNamespaces: []string{"team-a", "team-b"}},
+	})
+
+	older := newRoute("team-a", "r1", "example.com", "/shared", false, 1)
+	if err := p.HandleRoute(watch.Added, older); err != nil {
+		t.Fatalf("unexpected error admitting older route: %v", err)
+	}
+
+	youngerSamePath := newRoute("team-b", "r2", "example.com", "/shared", false, 2)
+	if err := p.HandleRoute(watch.Added, youngerSamePath); err == nil {
+		t.Fatalf("expected younger route to be rejected for contending the same path")
+	}
+
+	olderReclaims := newRoute("team-b", "r3", "example.com", "/shared", false, 0)
+	if err := p.HandleRoute(watch.Added, olderReclaims); err != nil {
+		t.Fatalf("unexpected error reclaiming path with an older route: %v", err)
+	}
+	if len(plugin.deleted) != 1 || plugin.deleted[0] != routeNameKey(older) {
+		t.Errorf("expected original path owner to be evicted, got deleted=%v", plugin.deleted)
+	}
+
+	otherPath := newRoute("team-a", "r4", "example.com", "/other", false, 1)
+	if err := p.HandleRoute(watch.Added, otherPath); err != nil {
+		t.Fatalf("unexpected error claiming a distinct path on the same host: %v", err)
+	}
+
+	routes, ok := p.RoutesForHost("example.com")
+	if !ok || len(routes) != 2 {
+		t.Fatalf("expected both the reclaimed and the distinct path to coexist, got %v (ok=%v)", routes, ok)
+	}
+}
+
+// TestHandleNamespaces_MultiNamespaceHost guards against HandleNamespaces
+// gating eviction on hostToRoute[host][0] alone: a host claimed by an
+// InterNamespaceAllowed rule can hold routes from more than one namespace,
+// and narrowing the namespace filter must evict only the routes whose own
+// namespace fell out of scope, regardless of where they sit in the bucket.
+func TestHandleNamespaces_MultiNamespaceHost(t *testing.T) {
+	p, plugin := newPolicyTestPlugin(t, []policy.AdminRouteAdmissionPolicy{
+		{Name: "shared", HostPattern: "example.com", Mode: policy.OwnershipInterNamespaceAllowed, AllowedNamespaces: []string{"team-a", "team-b"}},
+	})
+
+	a := newRoute("team-a", "r1", "example.com", "/a", false, 1)
+	b := newRoute("team-b", "r2", "example.com", "/b", false, 2)
+	if err := p.HandleRoute(watch.Added, a); err != nil {
+		t.Fatalf("unexpected error admitting team-a route: %v", err)
+	}
+	if err := p.HandleRoute(watch.Added, b); err != nil {
+		t.Fatalf("unexpected error admitting team-b route: %v", err)
+	}
+
+	if err := p.HandleNamespaces(sets.NewString("team-b")); err != nil {
+		t.Fatalf("unexpected error from HandleNamespaces: %v", err)
+	}
+
+	routes, ok := p.RoutesForHost("example.com")
+	if !ok || len(routes) != 1 || routes[0].Namespace != "team-b" {
+		t.Fatalf("expected only team-b's route to survive, got %v (ok=%v)", routes, ok)
+	}
+	if len(plugin.deleted) != 1 || plugin.deleted[0] != routeNameKey(a) {
+		t.Errorf("expected team-a's route to be forwarded as deleted, got deleted=%v", plugin.deleted)
+	}
+	if _, ok := p.routeToHost[routeNameKey(a)]; ok {
+		t.Errorf("routeToHost should no longer track the evicted team-a route")
+	}
+	if _, ok := p.routeToHost[routeNameKey(b)]; !ok {
+		t.Errorf("routeToHost should still track the surviving team-b route")
+	}
+
+	if err := p.HandleNamespaces(sets.NewString("team-a")); err != nil {
+		t.Fatalf("unexpected error narrowing HandleNamespaces further: %v", err)
+	}
+	if _, ok := p.RoutesForHost("example.com"); ok {
+		t.Errorf("expected the host to be fully evicted once no allowed namespace remains")
+	}
+}
@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"github.com/go-logr/logr"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/util/workqueue"
+
+	routeapi "github.com/openshift/origin/pkg/route/api"
+	"github.com/openshift/origin/pkg/router/log"
+)
+
+// StatusWriter persists the outcome of an admission decision onto
+// route.Status.Ingress, so that `oc describe route` reflects the decision
+// directly instead of requiring the rejection event or log line to be found.
+type StatusWriter interface {
+	UpdateRouteStatus(route *routeapi.Route, admitted bool, reason, message string)
+}
+
+// NoopStatusWriter is the default StatusWriter: it discards every update.
+// Used by tests and by callers that don't want the extra API traffic.
+var NoopStatusWriter StatusWriter = noopStatusWriter{}
+
+type noopStatusWriter struct{}
+
+func (noopStatusWriter) UpdateRouteStatus(*routeapi.Route, bool, string, string) {}
+
+// statusUpdate is the unit of work queued by ClientStatusWriter.
+type statusUpdate struct {
+	namespace, name string
+	condition       routeapi.RouteIngressCondition
+}
+
+// ClientStatusWriter is a StatusWriter that batches Route.Status.Ingress
+// updates through a rate-limited work queue with retry-on-conflict, so the
+// hot HandleRoute path is never blocked on an API write.
+type ClientStatusWriter struct {
+	routerName string
+	routes     kclient.RoutesNamespacer
+	queue      workqueue.RateLimitingInterface
+	log        logr.Logger
+}
+
+// NewClientStatusWriter returns a StatusWriter that records admission
+// decisions made by routerName against routes served by the given client.
+// It starts a background worker that drains the queue until Stop is called.
+func NewClientStatusWriter(routerName string, routes kclient.RoutesNamespacer) *ClientStatusWriter {
+	w := &ClientStatusWriter{
+		routerName: routerName,
+		routes:     routes,
+		queue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "router-status"),
+		log:        log.WithName("statusWriter"),
+	}
+	go w.run()
+	return w
+}
+
+// UpdateRouteStatus implements StatusWriter.
+func (w *ClientStatusWriter) UpdateRouteStatus(route *routeapi.Route, admitted bool, reason, message string) {
+	status := kapi.ConditionFalse
+	if admitted {
+		status = kapi.ConditionTrue
+	}
+	w.queue.Add(statusUpdate{
+		namespace: route.Namespace,
+		name:      route.Name,
+		condition: routeapi.RouteIngressCondition{
+			Type:               routeapi.RouteAdmitted,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: unversioned.Now(),
+		},
+	})
+}
+
+// Stop shuts the background worker down.
+func (w *ClientStatusWriter) Stop() {
+	w.queue.ShutDown()
+}
+
+func (w *ClientStatusWriter) run() {
+	for {
+		item, quit := w.queue.Get()
+		if quit {
+			return
+		}
+		u := item.(statusUpdate)
+		if err := w.sync(u); err != nil {
+			w.log.V(4).Info("requeuing status update", "namespace", u.namespace, "name", u.name, "error", err)
+			w.queue.AddRateLimited(u)
+		} else {
+			w.queue.Forget(u)
+		}
+		w.queue.Done(u)
+	}
+}
+
+// sync fetches the latest version of the route, merges the queued condition
+// into it, and writes the result back if anything changed.
+func (w *ClientStatusWriter) sync(u statusUpdate) error {
+	client := w.routes.Routes(u.namespace)
+	route, err := client.Get(u.name)
+	if err != nil {
+		return err
+	}
+	if !mergeIngressCondition(route, w.routerName, u.condition) {
+		return nil
+	}
+	_, err = client.UpdateStatus(route)
+	return err
+}
+
+// mergeIngressCondition folds condition into route's RouteIngressStatus for
+// routerName, creating the entry if it doesn't exist yet. It returns false
+// when the condition is identical to what's already recorded, so repeated
+// resyncs collapse into a no-op instead of spamming the API server.
+func mergeIngressCondition(route *routeapi.Route, routerName string, condition routeapi.RouteIngressCondition) bool {
+	for i := range route.Status.Ingress {
+		ingress := &route.Status.Ingress[i]
+		if ingress.RouterName != routerName {
+			continue
+		}
+		hostChanged := ingress.Host != route.Spec.Host
+		if hostChanged {
+			ingress.Host = route.Spec.Host
+		}
+		for j := range ingress.Conditions {
+			existing := &ingress.Conditions[j]
+			if existing.Type != condition.Type {
+				continue
+			}
+			if !hostChanged && existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message {
+				return false
+			}
+			ingress.Conditions[j] = condition
+			return true
+		}
+		ingress.Conditions = append(ingress.Conditions, condition)
+		return true
+	}
+	route.Status.Ingress = append(route.Status.Ingress, routeapi.RouteIngressStatus{
+		RouterName: routerName,
+		Host:       route.Spec.Host,
+		Conditions: []routeapi.RouteIngressCondition{condition},
+	})
+	return true
+}
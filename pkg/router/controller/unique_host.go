@@ -3,13 +3,15 @@ package controller
 import (
 	"fmt"
 
-	"github.com/golang/glog"
+	"github.com/go-logr/logr"
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/util/sets"
 	"k8s.io/kubernetes/pkg/watch"
 
 	routeapi "github.com/openshift/origin/pkg/route/api"
 	"github.com/openshift/origin/pkg/router"
+	"github.com/openshift/origin/pkg/router/controller/policy"
+	"github.com/openshift/origin/pkg/router/log"
 )
 
 // RouteHostFunc returns a host for a route. It may return an empty string.
@@ -28,12 +30,18 @@ type RejectionRecorder interface {
 	RecordRouteRejection(route *routeapi.Route, reason, message string)
 }
 
-var LogRejections = logRecorder{}
+// LogRejections is the default RejectionRecorder: it logs through
+// log.WithName("rejections"). The logger is looked up on every call rather
+// than cached, since LogRejections is a package-level var initialized
+// before main() has a chance to call log.SetLogger; caching log.WithName's
+// result here would permanently bind it to the discard logger in effect at
+// that point.
+var LogRejections RejectionRecorder = logRecorder{}
 
 type logRecorder struct{}
 
-func (_ logRecorder) RecordRouteRejection(route *routeapi.Route, reason, message string) {
-	glog.V(4).Infof("Rejected route %s: %s: %s", route.Name, reason, message)
+func (r logRecorder) RecordRouteRejection(route *routeapi.Route, reason, message string) {
+	log.WithName("rejections").V(4).Info("rejected route", "route", routeNameKey(route), "reason", reason, "message", message)
 }
 
 // UniqueHost implements the router.Plugin interface to provide
@@ -44,36 +52,79 @@ type UniqueHost struct {
 
 	recorder RejectionRecorder
 
-	hostToRoute HostToRouteMap
-	routeToHost RouteToHostMap
+	hostToRoute     HostToRouteMap
+	wildcardToRoute WildcardToRouteMap
+	routeToHost     RouteToHostMap
 	// nil means different than empty
 	allowedNamespaces sets.String
+
+	// policy, when set, is consulted before the oldest-wins claim logic below
+	// and can restrict which namespaces are allowed to claim a given host.
+	policy policy.PolicyProvider
+
+	// statusWriter persists every admission decision to the route's own
+	// Status.Ingress conditions, in addition to recorder's event/log.
+	statusWriter StatusWriter
+
+	log logr.Logger
 }
 
 // NewUniqueHost creates a plugin wrapper that ensures only unique routes are passed into
 // the underlying plugin. Recorder is an interface for indicating why a route was
-// rejected.
+// rejected. Logging goes to log.WithName("uniqueHost"); use
+// NewUniqueHostWithLogger to supply a different logger, e.g. one backed by a
+// testing sink.
 func NewUniqueHost(plugin router.Plugin, fn RouteHostFunc, recorder RejectionRecorder) *UniqueHost {
+	return NewUniqueHostWithLogger(plugin, fn, recorder, log.WithName("uniqueHost"))
+}
+
+// NewUniqueHostWithLogger is NewUniqueHost with an explicit logger.
+func NewUniqueHostWithLogger(plugin router.Plugin, fn RouteHostFunc, recorder RejectionRecorder, logger logr.Logger) *UniqueHost {
 	return &UniqueHost{
 		plugin:       plugin,
 		hostForRoute: fn,
 
 		recorder: recorder,
 
-		hostToRoute: make(HostToRouteMap),
-		routeToHost: make(RouteToHostMap),
+		hostToRoute:     make(HostToRouteMap),
+		wildcardToRoute: make(WildcardToRouteMap),
+		routeToHost:     make(RouteToHostMap),
+
+		statusWriter: NoopStatusWriter,
+		log:          logger,
 	}
 }
 
+// SetAdmissionPolicy installs the cluster-scoped policy used to decide which
+// namespaces may claim which hosts. A nil policy restores the default
+// behavior of the first namespace to claim a host owning it.
+func (p *UniqueHost) SetAdmissionPolicy(provider policy.PolicyProvider) {
+	p.policy = provider
+}
+
+// SetStatusWriter installs the writer used to persist admission decisions to
+// route.Status.Ingress. The default is NoopStatusWriter, which does nothing.
+func (p *UniqueHost) SetStatusWriter(writer StatusWriter) {
+	p.statusWriter = writer
+}
+
+// rejectRoute records why route was rejected or replaced, both through the
+// configured RejectionRecorder and onto the route's own status.
+func (p *UniqueHost) rejectRoute(route *routeapi.Route, reason, message string) {
+	p.recorder.RecordRouteRejection(route, reason, message)
+	p.statusWriter.UpdateRouteStatus(route, false, reason, message)
+}
+
 // RoutesForHost is a helper that allows routes to be retrieved.
 func (p *UniqueHost) RoutesForHost(host string) ([]*routeapi.Route, bool) {
 	routes, ok := p.hostToRoute[host]
 	return routes, ok
 }
 
-// HostLen returns the number of hosts currently tracked by this plugin.
+// HostLen returns the number of hosts currently tracked by this plugin,
+// counting both concrete hosts and wildcard suffixes.
 func (p *UniqueHost) HostLen() int {
-	return len(p.hostToRoute)
+	return len(p.hostToRoute) + len(p.wildcardToRoute)
 }
 
 // HandleEndpoints processes watch events on the Endpoints resource.
@@ -98,12 +149,64 @@ func (p *UniqueHost) HandleRoute(eventType watch.EventType, route *routeapi.Rout
 
 	host := p.hostForRoute(route)
 	if len(host) == 0 {
-		glog.V(4).Infof("Route %s has no host value", routeName)
-		p.recorder.RecordRouteRejection(route, "NoHostValue", "no host value was defined for the route")
+		p.log.V(4).Info("route has no host value", "route", routeName)
+		p.rejectRoute(route, "NoHostValue", "no host value was defined for the route")
 		return nil
 	}
 	route.Spec.Host = host
 
+	// consult the admission policy, if any, before falling through to the
+	// default oldest-wins claim logic below
+	if p.policy != nil {
+		if rule, ok := p.policy.Lookup(host); ok {
+			if !allowedByRule(rule, route.Namespace) {
+				err := fmt.Errorf("namespace %s is not permitted to claim host %s by admission policy %s", route.Namespace, host, rule.Name)
+				p.log.V(4).Info("route denied by admission policy", "route", routeName, "error", err)
+				p.rejectRoute(route, "HostDeniedByPolicy", err.Error())
+				return err
+			}
+			if rule.Mode == policy.OwnershipInterNamespaceAllowed {
+				if err := p.claimInterNamespaceHost(host, route, routeName); err != nil {
+					return err
+				}
+				return p.finishHandleRoute(eventType, route, key, routeName, host)
+			}
+			if rule.Mode == policy.OwnershipPathExclusive {
+				if err := p.claimPathExclusiveHost(host, route, routeName); err != nil {
+					return err
+				}
+				return p.finishHandleRoute(eventType, route, key, routeName, host)
+			}
+			// Strict falls through to the default logic below; the
+			// namespace allowlist check above already scopes participation
+			// to the rule's AllowedNamespaces.
+		}
+	}
+
+	// a route whose host is itself a wildcard subdomain claims the DNS
+	// suffix rather than the literal host
+	if suffix, isWildcard := wildcardSuffix(route, host); isWildcard {
+		if err := p.claimWildcardHost(suffix, route, routeName); err != nil {
+			return err
+		}
+		return p.finishHandleRoute(eventType, route, key, routeName, host)
+	}
+
+	// a concrete host can be shadowed by a wildcard suffix claimed by a
+	// different namespace; longest suffix wins, oldest route reclaims
+	if suffix, owner, ok := p.longestWildcardMatch(host); ok && owner.Namespace != route.Namespace {
+		if owner.CreationTimestamp.Before(route.CreationTimestamp) {
+			err := fmt.Errorf("wildcard route %s holds %s and is older than %s", routeNameKey(owner), suffix, route.Name)
+			p.rejectRoute(route, "HostConflictsWithWildcard", err.Error())
+			return err
+		}
+		for _, wildcardRoute := range p.wildcardToRoute[suffix] {
+			p.rejectRoute(wildcardRoute, "HostConflictsWithWildcard", fmt.Sprintf("namespace %s owns hostname %s", route.Namespace, host))
+			p.plugin.HandleRoute(watch.Deleted, wildcardRoute)
+		}
+		delete(p.wildcardToRoute, suffix)
+	}
+
 	// ensure hosts can only be claimed by one namespace at a time
 	// TODO: this could be abstracted above this layer?
 	if old, ok := p.hostToRoute[host]; ok {
@@ -115,9 +218,9 @@ func (p *UniqueHost) HandleRoute(eventType watch.EventType, route *routeapi.Rout
 			for i := range old {
 				if old[i].Spec.Path == route.Spec.Path {
 					if old[i].CreationTimestamp.Before(route.CreationTimestamp) {
-						glog.V(4).Infof("Route %s cannot take %s from %s", routeName, host, routeNameKey(oldest))
+						p.log.V(4).Info("route cannot take host from older route", "route", routeName, "host", host, "owner", routeNameKey(oldest))
 						err := fmt.Errorf("route %s already exposes %s and is older", oldest.Name, host)
-						p.recorder.RecordRouteRejection(route, "HostAlreadyClaimed", err.Error())
+						p.rejectRoute(route, "HostAlreadyClaimed", err.Error())
 						return err
 					}
 					added = true
@@ -125,8 +228,8 @@ func (p *UniqueHost) HandleRoute(eventType watch.EventType, route *routeapi.Rout
 						old[i] = route
 						break
 					}
-					glog.V(4).Infof("route %s will replace path %s from %s because it is older", routeName, route.Spec.Path, old[i].Name)
-					p.recorder.RecordRouteRejection(old[i], "HostAlreadyClaimed", fmt.Sprintf("replaced by older route %s", route.Name))
+					p.log.V(4).Info("route replaces older path owner", "route", routeName, "path", route.Spec.Path, "replaced", old[i].Name)
+					p.rejectRoute(old[i], "HostAlreadyClaimed", fmt.Sprintf("replaced by older route %s", route.Name))
 					p.plugin.HandleRoute(watch.Deleted, old[i])
 					old[i] = route
 				}
@@ -140,48 +243,58 @@ func (p *UniqueHost) HandleRoute(eventType watch.EventType, route *routeapi.Rout
 			}
 		} else {
 			if oldest.CreationTimestamp.Before(route.CreationTimestamp) {
-				glog.V(4).Infof("Route %s cannot take %s from %s", routeName, host, routeNameKey(oldest))
+				p.log.V(4).Info("route cannot take host from older route", "route", routeName, "host", host, "owner", routeNameKey(oldest))
 				err := fmt.Errorf("another route holds %s and is older than %s", host, route.Name)
-				p.recorder.RecordRouteRejection(route, "HostAlreadyClaimed", err.Error())
+				p.rejectRoute(route, "HostAlreadyClaimed", err.Error())
 				return err
 			}
 
-			glog.V(4).Infof("Route %s is reclaiming %s from namespace %s", routeName, host, oldest.Namespace)
+			p.log.V(4).Info("route reclaims host from another namespace", "route", routeName, "host", host, "fromNamespace", oldest.Namespace)
 			for i := range old {
-				p.recorder.RecordRouteRejection(old[i], "HostAlreadyClaimed", fmt.Sprintf("namespace %s owns hostname %s", oldest.Namespace, host))
+				p.rejectRoute(old[i], "HostAlreadyClaimed", fmt.Sprintf("namespace %s owns hostname %s", oldest.Namespace, host))
 				p.plugin.HandleRoute(watch.Deleted, old[i])
 			}
 			p.hostToRoute[host] = []*routeapi.Route{route}
 		}
 	} else {
-		glog.V(4).Infof("Route %s claims %s", key, host)
+		p.log.V(4).Info("route claims host", "route", key, "host", host)
 		p.hostToRoute[host] = []*routeapi.Route{route}
 	}
 
+	return p.finishHandleRoute(eventType, route, key, routeName, host)
+}
+
+// finishHandleRoute applies the routeToHost bookkeeping common to every
+// admission path and forwards the event to the wrapped plugin. It assumes
+// p.hostToRoute[host] or p.wildcardToRoute[suffix] has already been updated
+// to reflect the claim decision.
+func (p *UniqueHost) finishHandleRoute(eventType watch.EventType, route *routeapi.Route, key, routeName, host string) error {
 	switch eventType {
 	case watch.Added, watch.Modified:
 		if old, ok := p.routeToHost[routeName]; ok {
 			if old != host {
-				glog.V(4).Infof("Route %s changed from serving host %s to host %s", key, old, host)
-				delete(p.hostToRoute, old)
+				p.log.V(4).Info("route changed host", "route", key, "fromHost", old, "toHost", host)
+				p.deleteClaim(old)
 			}
 		}
 		p.routeToHost[routeName] = host
+		p.statusWriter.UpdateRouteStatus(route, true, "", "")
 		return p.plugin.HandleRoute(eventType, route)
 
 	case watch.Deleted:
-		glog.V(4).Infof("Deleting routes for %s", key)
-		if old, ok := p.hostToRoute[host]; ok {
-			switch len(old) {
-			case 1, 0:
-				delete(p.hostToRoute, host)
-			default:
-				next := []*routeapi.Route{}
-				for i := range old {
-					if old[i].Name != route.Name {
-						next = append(next, old[i])
-					}
+		p.log.V(4).Info("deleting route", "route", key)
+		if suffix, isWildcard := stripWildcardPrefix(host); isWildcard {
+			if old, ok := p.wildcardToRoute[suffix]; ok {
+				if next := removeRoute(old, route.Name); len(next) == 0 {
+					delete(p.wildcardToRoute, suffix)
+				} else {
+					p.wildcardToRoute[suffix] = next
 				}
+			}
+		} else if old, ok := p.hostToRoute[host]; ok {
+			if next := removeRoute(old, route.Name); len(next) == 0 {
+				delete(p.hostToRoute, host)
+			} else {
 				p.hostToRoute[host] = next
 			}
 		}
@@ -191,20 +304,120 @@ func (p *UniqueHost) HandleRoute(eventType watch.EventType, route *routeapi.Rout
 	return nil
 }
 
+// allowedByRule reports whether namespace may participate in the claim of a
+// host matched by rule.
+func allowedByRule(rule policy.AdminRouteAdmissionPolicy, namespace string) bool {
+	for _, ns := range rule.AllowedNamespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// claimInterNamespaceHost admits route onto host under the
+// InterNamespaceAllowed ownership mode: routes from different namespaces may
+// share the host as long as none of them already serve the same path.
+func (p *UniqueHost) claimInterNamespaceHost(host string, route *routeapi.Route, routeName string) error {
+	old, ok := p.hostToRoute[host]
+	if !ok {
+		p.log.V(4).Info("route claims host", "route", routeName, "host", host)
+		p.hostToRoute[host] = []*routeapi.Route{route}
+		return nil
+	}
+
+	for i := range old {
+		if old[i].Namespace == route.Namespace && old[i].Name == route.Name {
+			old[i] = route
+			return nil
+		}
+		if old[i].Spec.Path == route.Spec.Path {
+			err := fmt.Errorf("route %s in namespace %s already exposes path %q on %s", old[i].Name, old[i].Namespace, route.Spec.Path, host)
+			p.log.V(4).Info("route conflicts with existing path owner", "route", routeName, "error", err)
+			p.rejectRoute(route, "PathConflict", err.Error())
+			return err
+		}
+	}
+	p.hostToRoute[host] = append(old, route)
+	return nil
+}
+
+// claimPathExclusiveHost admits route onto host under the PathExclusive
+// ownership mode: namespaces may share a host as long as they don't serve
+// the same path, and when two namespaces contend for the same path the
+// oldest route wins that path, mirroring the default host-level
+// oldest-wins tie-break but scoped to a single path rather than the whole
+// host.
+func (p *UniqueHost) claimPathExclusiveHost(host string, route *routeapi.Route, routeName string) error {
+	old, ok := p.hostToRoute[host]
+	if !ok {
+		p.log.V(4).Info("route claims host", "route", routeName, "host", host)
+		p.hostToRoute[host] = []*routeapi.Route{route}
+		return nil
+	}
+
+	for i := range old {
+		if old[i].Spec.Path != route.Spec.Path {
+			continue
+		}
+		if old[i].Namespace == route.Namespace && old[i].Name == route.Name {
+			old[i] = route
+			return nil
+		}
+		if old[i].CreationTimestamp.Before(route.CreationTimestamp) {
+			err := fmt.Errorf("route %s in namespace %s already exposes path %q on %s and is older", old[i].Name, old[i].Namespace, route.Spec.Path, host)
+			p.log.V(4).Info("route cannot take path from older route", "route", routeName, "host", host, "path", route.Spec.Path, "owner", routeNameKey(old[i]))
+			p.rejectRoute(route, "PathConflict", err.Error())
+			return err
+		}
+		p.log.V(4).Info("route reclaims path from another namespace", "route", routeName, "host", host, "path", route.Spec.Path, "fromNamespace", old[i].Namespace)
+		p.rejectRoute(old[i], "PathConflict", fmt.Sprintf("namespace %s claims path %q on %s", route.Namespace, route.Spec.Path, host))
+		p.plugin.HandleRoute(watch.Deleted, old[i])
+		old[i] = route
+		return nil
+	}
+	p.hostToRoute[host] = append(old, route)
+	return nil
+}
+
 // HandleAllowedNamespaces limits the scope of valid routes to only those that match
 // the provided namespace list.
 func (p *UniqueHost) HandleNamespaces(namespaces sets.String) error {
 	p.allowedNamespaces = namespaces
 	changed := false
 	for k, v := range p.hostToRoute {
-		if namespaces.Has(v[0].Namespace) {
-			continue
+		kept := []*routeapi.Route{}
+		for _, route := range v {
+			if namespaces.Has(route.Namespace) {
+				kept = append(kept, route)
+				continue
+			}
+			delete(p.routeToHost, routeNameKey(route))
+			p.plugin.HandleRoute(watch.Deleted, route)
+			changed = true
+		}
+		if len(kept) == 0 {
+			delete(p.hostToRoute, k)
+		} else {
+			p.hostToRoute[k] = kept
 		}
-		delete(p.hostToRoute, k)
-		for i := range v {
-			delete(p.routeToHost, routeNameKey(v[i]))
+	}
+	for k, v := range p.wildcardToRoute {
+		kept := []*routeapi.Route{}
+		for _, route := range v {
+			if namespaces.Has(route.Namespace) {
+				kept = append(kept, route)
+				continue
+			}
+			delete(p.routeToHost, routeNameKey(route))
+			p.plugin.HandleRoute(watch.Deleted, route)
+			changed = true
+		}
+		if len(kept) == 0 {
+			delete(p.wildcardToRoute, k)
+		} else {
+			p.wildcardToRoute[k] = kept
 		}
-		changed = true
 	}
 	if !changed && len(namespaces) > 0 {
 		return nil
@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/watch"
+
+	routeapi "github.com/openshift/origin/pkg/route/api"
+)
+
+// WildcardToRouteMap indexes routes claiming a wildcard subdomain by the DNS
+// suffix they were admitted under, e.g. "example.com" for a route serving
+// "*.example.com".
+type WildcardToRouteMap map[string][]*routeapi.Route
+
+// RoutesForWildcard is a helper that allows the routes claiming a wildcard
+// suffix to be retrieved.
+func (p *UniqueHost) RoutesForWildcard(suffix string) ([]*routeapi.Route, bool) {
+	routes, ok := p.wildcardToRoute[normalizeWildcardHost(suffix)]
+	return routes, ok
+}
+
+// wildcardSuffix returns the normalized DNS suffix route should claim as a
+// wildcard, and whether it is a wildcard claim at all. A route is a wildcard
+// claim when its host is itself of the form "*.suffix", or when it opts in
+// via WildcardPolicySubdomain.
+func wildcardSuffix(route *routeapi.Route, host string) (string, bool) {
+	if strings.HasPrefix(host, "*.") {
+		return normalizeWildcardHost(strings.TrimPrefix(host, "*.")), true
+	}
+	if route.Spec.WildcardPolicy == routeapi.WildcardPolicySubdomain {
+		if i := strings.IndexByte(host, '.'); i >= 0 {
+			return normalizeWildcardHost(host[i+1:]), true
+		}
+	}
+	return "", false
+}
+
+// stripWildcardPrefix returns the normalized suffix of host when host is
+// itself a wildcard host ("*.suffix"), and whether it was one.
+func stripWildcardPrefix(host string) (string, bool) {
+	if !strings.HasPrefix(host, "*.") {
+		return "", false
+	}
+	return normalizeWildcardHost(strings.TrimPrefix(host, "*.")), true
+}
+
+// normalizeWildcardHost lower-cases host and strips a single trailing dot, so
+// that suffixes compare equal regardless of case or a trailing root dot.
+func normalizeWildcardHost(host string) string {
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
+
+// longestWildcardMatch returns the longest wildcard suffix claimed by this
+// plugin that host is a member of, along with the route that currently owns
+// it (the oldest route claiming that suffix).
+func (p *UniqueHost) longestWildcardMatch(host string) (string, *routeapi.Route, bool) {
+	host = normalizeWildcardHost(host)
+	bestSuffix := ""
+	var bestOwner *routeapi.Route
+	for suffix, routes := range p.wildcardToRoute {
+		if len(routes) == 0 {
+			continue
+		}
+		if host != suffix && !strings.HasSuffix(host, "."+suffix) {
+			continue
+		}
+		if len(suffix) > len(bestSuffix) {
+			bestSuffix = suffix
+			bestOwner = routes[0]
+		}
+	}
+	if bestOwner == nil {
+		return "", nil, false
+	}
+	return bestSuffix, bestOwner, true
+}
+
+// claimWildcardHost admits route as the (or another) owner of the wildcard
+// suffix, applying the same oldest-wins and same-namespace-multipath
+// semantics as the concrete host claim logic, plus a check against any
+// concrete hosts already claimed under the suffix from another namespace.
+func (p *UniqueHost) claimWildcardHost(suffix string, route *routeapi.Route, routeName string) error {
+	// find every concrete host shadowed by this suffix before touching any
+	// state: if even one conflicting owner is older than route, the whole
+	// wildcard claim is rejected and nothing should have been evicted.
+	var shadowed []string
+	for concreteHost, owners := range p.hostToRoute {
+		if len(owners) == 0 {
+			continue
+		}
+		if concreteHost != suffix && !strings.HasSuffix(concreteHost, "."+suffix) {
+			continue
+		}
+		owner := owners[0]
+		if owner.Namespace == route.Namespace {
+			continue
+		}
+		if owner.CreationTimestamp.Before(route.CreationTimestamp) {
+			err := fmt.Errorf("route %s already exposes %s and is older", owner.Name, concreteHost)
+			p.rejectRoute(route, "HostConflictsWithWildcard", err.Error())
+			return err
+		}
+		shadowed = append(shadowed, concreteHost)
+	}
+	for _, concreteHost := range shadowed {
+		owner := p.hostToRoute[concreteHost][0]
+		p.rejectRoute(owner, "HostConflictsWithWildcard", fmt.Sprintf("namespace %s owns wildcard %s", route.Namespace, suffix))
+		p.plugin.HandleRoute(watch.Deleted, owner)
+		delete(p.hostToRoute, concreteHost)
+	}
+
+	old, ok := p.wildcardToRoute[suffix]
+	if !ok {
+		p.wildcardToRoute[suffix] = []*routeapi.Route{route}
+		return nil
+	}
+
+	oldest := old[0]
+	if oldest.Namespace == route.Namespace {
+		added := false
+		for i := range old {
+			if old[i].Spec.Path != route.Spec.Path {
+				continue
+			}
+			if old[i].CreationTimestamp.Before(route.CreationTimestamp) {
+				err := fmt.Errorf("route %s already exposes wildcard %s and is older", oldest.Name, suffix)
+				p.rejectRoute(route, "WildcardHostAlreadyClaimed", err.Error())
+				return err
+			}
+			added = true
+			if old[i].Namespace == route.Namespace && old[i].Name == route.Name {
+				old[i] = route
+				break
+			}
+			p.rejectRoute(old[i], "WildcardHostAlreadyClaimed", fmt.Sprintf("replaced by older route %s", route.Name))
+			p.plugin.HandleRoute(watch.Deleted, old[i])
+			old[i] = route
+		}
+		if !added {
+			if route.CreationTimestamp.Before(oldest.CreationTimestamp) {
+				p.wildcardToRoute[suffix] = append([]*routeapi.Route{route}, old...)
+			} else {
+				p.wildcardToRoute[suffix] = append(old, route)
+			}
+		}
+		return nil
+	}
+
+	if oldest.CreationTimestamp.Before(route.CreationTimestamp) {
+		err := fmt.Errorf("another route holds wildcard %s and is older than %s", suffix, route.Name)
+		p.rejectRoute(route, "WildcardHostAlreadyClaimed", err.Error())
+		return err
+	}
+	for i := range old {
+		p.rejectRoute(old[i], "WildcardHostAlreadyClaimed", fmt.Sprintf("namespace %s owns wildcard %s", route.Namespace, suffix))
+		p.plugin.HandleRoute(watch.Deleted, old[i])
+	}
+	p.wildcardToRoute[suffix] = []*routeapi.Route{route}
+	return nil
+}
+
+// removeRoute returns list with the route named name removed.
+func removeRoute(list []*routeapi.Route, name string) []*routeapi.Route {
+	next := []*routeapi.Route{}
+	for i := range list {
+		if list[i].Name != name {
+			next = append(next, list[i])
+		}
+	}
+	return next
+}
+
+// deleteClaim removes host from whichever claim map currently holds it.
+func (p *UniqueHost) deleteClaim(host string) {
+	if suffix, ok := stripWildcardPrefix(host); ok {
+		delete(p.wildcardToRoute, suffix)
+		return
+	}
+	delete(p.hostToRoute, host)
+}
@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/util/sets"
+	"k8s.io/kubernetes/pkg/watch"
+
+	routeapi "github.com/openshift/origin/pkg/route/api"
+)
+
+// testPlugin is a router.Plugin that just records the events it was handed,
+// so tests can assert on what UniqueHost forwarded (or evicted).
+type testPlugin struct {
+	added   []string
+	deleted []string
+}
+
+func (p *testPlugin) HandleRoute(eventType watch.EventType, route *routeapi.Route) error {
+	switch eventType {
+	case watch.Deleted:
+		p.deleted = append(p.deleted, routeNameKey(route))
+	default:
+		p.added = append(p.added, routeNameKey(route))
+	}
+	return nil
+}
+
+func (p *testPlugin) HandleEndpoints(eventType watch.EventType, endpoints *kapi.Endpoints) error {
+	return nil
+}
+
+func (p *testPlugin) HandleNamespaces(namespaces sets.String) error { return nil }
+
+func ts(seconds int64) unversioned.Time {
+	return unversioned.NewTime(time.Unix(seconds, 0))
+}
+
+func newRoute(namespace, name, host, path string, wildcard bool, created int64) *routeapi.Route {
+	route := &routeapi.Route{
+		ObjectMeta: kapi.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			CreationTimestamp: ts(created),
+		},
+		Spec: routeapi.RouteSpec{
+			Host: host,
+			Path: path,
+		},
+	}
+	if wildcard {
+		route.Spec.WildcardPolicy = routeapi.WildcardPolicySubdomain
+	}
+	return route
+}
+
+func TestNormalizeWildcardHost(t *testing.T) {
+	cases := map[string]string{
+		"example.com":  "example.com",
+		"Example.COM":  "example.com",
+		"example.com.": "example.com",
+		"EXAMPLE.com.": "example.com",
+		"":             "",
+	}
+	for in, want := range cases {
+		if got := normalizeWildcardHost(in); got != want {
+			t.Errorf("normalizeWildcardHost(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestWildcardSuffix(t *testing.T) {
+	cases := []struct {
+		name     string
+		route    *routeapi.Route
+		host     string
+		suffix   string
+		isWild   bool
+	}{
+		{
+			name:   "literal wildcard host",
+			route:  newRoute("a", "r1", "*.Example.com.", "", false, 1),
+			host:   "*.Example.com.",
+			suffix: "example.com",
+			isWild: true,
+		},
+		{
+			name:   "subdomain policy derives suffix",
+			route:  newRoute("a", "r2", "foo.example.com", "", true, 1),
+			host:   "foo.example.com",
+			suffix: "example.com",
+			isWild: true,
+		},
+		{
+			name:   "concrete host without policy is not a wildcard",
+			route:  newRoute("a", "r3", "foo.example.com", "", false, 1),
+			host:   "foo.example.com",
+			isWild: false,
+		},
+	}
+	for _, tc := range cases {
+		suffix, isWild := wildcardSuffix(tc.route, tc.host)
+		if isWild != tc.isWild || (isWild && suffix != tc.suffix) {
+			t.Errorf("%s: wildcardSuffix() = (%q, %v), want (%q, %v)", tc.name, suffix, isWild, tc.suffix, tc.isWild)
+		}
+	}
+}
+
+func TestClaimWildcardHost_ConcreteOlderWinsOverWildcard(t *testing.T) {
+	plugin := &testPlugin{}
+	p := NewUniqueHost(plugin, HostForRoute, LogRejections)
+
+	concrete := newRoute("team-a", "svc", "foo.example.com", "", false, 1)
+	if err := p.HandleRoute(watch.Added, concrete); err != nil {
+		t.Fatalf("unexpected error admitting concrete route: %v", err)
+	}
+
+	wildcard := newRoute("team-b", "wild", "*.example.com", "", false, 2)
+	if err := p.HandleRoute(watch.Added, wildcard); err == nil {
+		t.Fatalf("expected wildcard claim to be rejected by older concrete route")
+	}
+
+	if _, ok := p.wildcardToRoute["example.com"]; ok {
+		t.Errorf("wildcard suffix should not have been claimed")
+	}
+	if len(plugin.deleted) != 0 {
+		t.Errorf("no route should have been evicted, got %v", plugin.deleted)
+	}
+	if routes, ok := p.RoutesForHost("foo.example.com"); !ok || len(routes) != 1 {
+		t.Errorf("concrete route claim should be untouched, got %v (ok=%v)", routes, ok)
+	}
+}
+
+func TestClaimWildcardHost_NamespaceReclaimFromConcrete(t *testing.T) {
+	plugin := &testPlugin{}
+	p := NewUniqueHost(plugin, HostForRoute, LogRejections)
+
+	concrete := newRoute("team-a", "svc", "foo.example.com", "", false, 2)
+	if err := p.HandleRoute(watch.Added, concrete); err != nil {
+		t.Fatalf("unexpected error admitting concrete route: %v", err)
+	}
+
+	wildcard := newRoute("team-b", "wild", "*.example.com", "", false, 1)
+	if err := p.HandleRoute(watch.Added, wildcard); err != nil {
+		t.Fatalf("unexpected error admitting older wildcard route: %v", err)
+	}
+
+	if _, ok := p.RoutesForHost("foo.example.com"); ok {
+		t.Errorf("concrete claim should have been evicted")
+	}
+	if len(plugin.deleted) != 1 || plugin.deleted[0] != routeNameKey(concrete) {
+		t.Errorf("expected concrete route to be evicted, got deleted=%v", plugin.deleted)
+	}
+	routes, ok := p.RoutesForWildcard("example.com")
+	if !ok || len(routes) != 1 || routes[0].Name != "wild" {
+		t.Errorf("expected wildcard claim by team-b, got %v (ok=%v)", routes, ok)
+	}
+}
+
+func TestClaimWildcardHost_DeletionCleansBothMaps(t *testing.T) {
+	plugin := &testPlugin{}
+	p := NewUniqueHost(plugin, HostForRoute, LogRejections)
+
+	wildcard := newRoute("team-a", "wild", "*.example.com", "", false, 1)
+	if err := p.HandleRoute(watch.Added, wildcard); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.HostLen() != 1 {
+		t.Fatalf("expected HostLen()==1 after wildcard claim, got %d", p.HostLen())
+	}
+
+	if err := p.HandleRoute(watch.Deleted, wildcard); err != nil {
+		t.Fatalf("unexpected error deleting wildcard route: %v", err)
+	}
+	if _, ok := p.RoutesForWildcard("example.com"); ok {
+		t.Errorf("wildcardToRoute should be empty after delete")
+	}
+	if _, ok := p.routeToHost[routeNameKey(wildcard)]; ok {
+		t.Errorf("routeToHost should be empty after delete")
+	}
+	if p.HostLen() != 0 {
+		t.Errorf("expected HostLen()==0 after delete, got %d", p.HostLen())
+	}
+}
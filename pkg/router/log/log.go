@@ -0,0 +1,27 @@
+// Package log provides the structured logger shared by the router
+// controllers. It wraps logr.Logger so that callers log key/value pairs
+// instead of format strings, while keeping the glog-style V(level) verbosity
+// gating the controllers already depend on for their most chatty tracing.
+package log
+
+import (
+	"github.com/go-logr/logr"
+)
+
+// base is the process-wide logger. It defaults to discarding everything so
+// that packages which import log but never call SetLogger don't panic.
+var base logr.Logger = logr.Discard()
+
+// SetLogger installs l as the process-wide logger. main() calls this once,
+// after parsing the log format/verbosity flags, before starting any
+// controllers.
+func SetLogger(l logr.Logger) {
+	base = l
+}
+
+// WithName returns a sub-logger tagged with name, e.g. log.WithName("uniqueHost").
+// Controllers hold onto the result rather than calling WithName on every log
+// line.
+func WithName(name string) logr.Logger {
+	return base.WithName(name)
+}
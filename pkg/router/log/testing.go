@@ -0,0 +1,88 @@
+package log
+
+import (
+	"sync"
+
+	"github.com/go-logr/logr"
+)
+
+// Line is a single call captured by Sink.
+type Line struct {
+	Level         int
+	Name          string
+	Message       string
+	Error         error
+	KeysAndValues []interface{}
+}
+
+// Sink is a logr.LogSink that records every Info/Error call instead of
+// writing it anywhere, so tests can assert on the log lines a controller
+// emitted. Use NewTestLogger to obtain one wired up to a logr.Logger.
+type Sink struct {
+	mu    *sync.Mutex
+	lines *[]Line
+
+	name   string
+	values []interface{}
+}
+
+// NewTestLogger returns a logr.Logger backed by a fresh Sink.
+func NewTestLogger() (logr.Logger, *Sink) {
+	s := &Sink{mu: &sync.Mutex{}, lines: &[]Line{}}
+	return logr.New(s), s
+}
+
+// Lines returns a copy of the lines recorded so far.
+func (s *Sink) Lines() []Line {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Line, len(*s.lines))
+	copy(out, *s.lines)
+	return out
+}
+
+func (s *Sink) Init(logr.RuntimeInfo) {}
+
+func (s *Sink) Enabled(level int) bool { return true }
+
+func (s *Sink) Info(level int, msg string, keysAndValues ...interface{}) {
+	s.record(Line{
+		Level:         level,
+		Name:          s.name,
+		Message:       msg,
+		KeysAndValues: s.merge(keysAndValues),
+	})
+}
+
+func (s *Sink) Error(err error, msg string, keysAndValues ...interface{}) {
+	s.record(Line{
+		Name:          s.name,
+		Message:       msg,
+		Error:         err,
+		KeysAndValues: s.merge(keysAndValues),
+	})
+}
+
+func (s *Sink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &Sink{mu: s.mu, lines: s.lines, name: s.name, values: s.merge(keysAndValues)}
+}
+
+func (s *Sink) WithName(name string) logr.LogSink {
+	if s.name != "" {
+		name = s.name + "." + name
+	}
+	return &Sink{mu: s.mu, lines: s.lines, name: name, values: s.values}
+}
+
+func (s *Sink) merge(keysAndValues []interface{}) []interface{} {
+	out := make([]interface{}, 0, len(s.values)+len(keysAndValues))
+	out = append(out, s.values...)
+	out = append(out, keysAndValues...)
+	return out
+}
+
+func (s *Sink) record(line Line) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	*s.lines = append(*s.lines, line)
+}
@@ -0,0 +1,35 @@
+package log
+
+import (
+	"github.com/go-logr/logr"
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewZapLogger returns a production logr.Logger backed by zap. verbosity
+// follows the same convention as glog's -v flag: V(0) is always enabled,
+// and each increment of verbosity enables one more V(n) call site.
+func NewZapLogger(verbosity int) (logr.Logger, error) {
+	cfg := zap.NewProductionConfig()
+	cfg.Level = zap.NewAtomicLevelAt(zapcore.Level(-verbosity))
+	zl, err := cfg.Build()
+	if err != nil {
+		return logr.Discard(), err
+	}
+	return zapr.NewLogger(zl), nil
+}
+
+// NewZapLoggerText returns a logr.Logger backed by zap using its
+// human-readable console encoding rather than NewZapLogger's JSON, for a
+// router running attached to a terminal instead of shipping logs to an
+// aggregator. verbosity has the same meaning as in NewZapLogger.
+func NewZapLoggerText(verbosity int) (logr.Logger, error) {
+	cfg := zap.NewDevelopmentConfig()
+	cfg.Level = zap.NewAtomicLevelAt(zapcore.Level(-verbosity))
+	zl, err := cfg.Build()
+	if err != nil {
+		return logr.Discard(), err
+	}
+	return zapr.NewLogger(zl), nil
+}